@@ -0,0 +1,184 @@
+// Command pgxgen generates typed query wrapper methods from annotated SQL files.
+//
+// Usage:
+//
+//	pgxgen -dsn "$DATABASE_URL" -sql-dir ./sql -out ./queries.gen.go -package queries \
+//	    -go-type hstore=map[string]string -go-type date=time.Time
+//
+// pgxgen connects to the database named by -dsn (defaulting to the PGX_TEST_DATABASE
+// environment variable) to describe each query's parameters and result columns, so a
+// reachable PostgreSQL server with the target schema applied is required to run it.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxgen"
+)
+
+type goTypeFlags []string
+
+func (f *goTypeFlags) String() string { return fmt.Sprint([]string(*f)) }
+func (f *goTypeFlags) Set(s string) error {
+	*f = append(*f, s)
+	return nil
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("pgxgen", flag.ContinueOnError)
+	sqlDir := fs.String("sql-dir", ".", "directory of .sql files to parse")
+	out := fs.String("out", "queries.gen.go", "output file path")
+	pkgName := fs.String("package", "queries", "package name of the generated file")
+	dsn := fs.String("dsn", os.Getenv("PGX_TEST_DATABASE"), "connection string used to describe queries")
+	configPath := fs.String("config", "", "optional YAML config file (see pgxgen.Config)")
+	var goTypes goTypeFlags
+	fs.Var(&goTypes, "go-type", "pgtype=gotype override, may be repeated")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	overrides, err := collectOverrides(goTypes, *configPath)
+	if err != nil {
+		return err
+	}
+
+	queries, err := parseSQLDir(*sqlDir)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	pgConn, err := pgconn.Connect(ctx, *dsn)
+	if err != nil {
+		return fmt.Errorf("pgxgen: connecting: %w", err)
+	}
+	defer pgConn.Close(ctx)
+
+	ci := pgtype.NewConnInfo()
+	for _, o := range overrides {
+		if o.PgType == "hstore" {
+			if err := pgtype.RegisterHstoreType(ctx, ci, lookupOID(pgConn)); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	resolver := pgxgen.NewResolver(pgConn, ci, overrides)
+
+	resolved := make([]pgxgen.ResolvedQuery, 0, len(queries))
+	for _, q := range queries {
+		rq, err := resolver.Resolve(ctx, q)
+		if err != nil {
+			return err
+		}
+		resolved = append(resolved, rq)
+	}
+
+	src, err := pgxgen.Generate(*pkgName, resolved)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		return fmt.Errorf("pgxgen: writing %s: %w", *out, err)
+	}
+
+	return nil
+}
+
+// lookupOID adapts a raw *pgconn.PgConn to the lookupOID callback shape
+// pgtype.RegisterType expects: run sql via the simple query protocol and return the
+// single uint32 column of its single row.
+func lookupOID(pgConn *pgconn.PgConn) func(ctx context.Context, sql string) (uint32, error) {
+	return func(ctx context.Context, sql string) (uint32, error) {
+		results, err := pgConn.Exec(ctx, sql).ReadAll()
+		if err != nil {
+			return 0, err
+		}
+		if len(results) == 0 || len(results[0].Rows) == 0 {
+			return 0, nil
+		}
+		oid, err := strconv.ParseUint(string(results[0].Rows[0][0]), 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("pgxgen: parsing OID: %w", err)
+		}
+		return uint32(oid), nil
+	}
+}
+
+func collectOverrides(flags goTypeFlags, configPath string) ([]pgxgen.TypeOverride, error) {
+	var overrides []pgxgen.TypeOverride
+
+	if configPath != "" {
+		src, err := os.ReadFile(configPath)
+		if err != nil {
+			return nil, fmt.Errorf("pgxgen: reading config: %w", err)
+		}
+		cfg, err := pgxgen.LoadConfig(src)
+		if err != nil {
+			return nil, err
+		}
+		cfgOverrides, err := cfg.TypeOverrides()
+		if err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, cfgOverrides...)
+	}
+
+	for _, f := range flags {
+		o, err := pgxgen.ParseGoTypeFlag(f)
+		if err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, o)
+	}
+
+	return overrides, nil
+}
+
+func parseSQLDir(dir string) ([]pgxgen.Query, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && filepath.Ext(path) == ".sql" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pgxgen: walking %s: %w", dir, err)
+	}
+	sort.Strings(files)
+
+	var queries []pgxgen.Query
+	for _, path := range files {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		fileQueries, err := pgxgen.ParseQueries(path, src)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, fileQueries...)
+	}
+	return queries, nil
+}
@@ -0,0 +1,65 @@
+package pgx
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// NestedBeginner is the subset of DBTX that can begin a transaction. It is satisfied by
+// both *Conn and Tx, since the Tx returned from Begin also implements Begin itself
+// (implemented internally with savepoints). This makes it possible to write helpers that
+// open a transaction without caring whether they were handed a top-level connection or an
+// already-open transaction.
+type NestedBeginner interface {
+	Begin(ctx context.Context) (Tx, error)
+}
+
+// DBTX is satisfied by *Conn, Tx, and *pgxpool.Pool. Code that only needs to run queries
+// and does not care whether it is working against a single connection, an open
+// transaction, or a pool can accept a DBTX instead of a concrete type. This is
+// particularly useful for repository-style functions and for generated code (see
+// cmd/pgxgen) that must work the same way no matter what the caller passes in.
+//
+//	func FindUserByID(ctx context.Context, db pgx.DBTX, id int32) (User, error) {
+//		row := db.QueryRow(ctx, "select id, name from users where id = $1", id)
+//		var u User
+//		err := row.Scan(&u.ID, &u.Name)
+//		return u, err
+//	}
+//
+//	// FindUserByID can now be called with a *pgx.Conn, a pgx.Tx, or a *pgxpool.Pool:
+//	user, err := FindUserByID(ctx, conn, 42)
+//	user, err := FindUserByID(ctx, pool, 42)
+//	err = pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+//		user, err := FindUserByID(ctx, tx, 42)
+//		return err
+//	})
+//
+// DBTX intentionally stops at Begin. BeginTx needs a txOptions-aware Beginner, and Tx's
+// nested transactions are implemented with savepoints, which have no equivalent of
+// TxOptions — there is no sensible BeginTx for a Tx to implement, so DBTX cannot require
+// one uniformly. BeginFunc and BeginTxFunc are likewise left off DBTX: they are
+// package-level generic functions, not methods, and already accept any Beginner or
+// BeginTxer (both satisfied by *Conn; Tx only satisfies Beginner, for the reason above).
+// Callers that want begin-and-run-in-one-call semantics use those functions directly, the
+// same way the FindUserByID example above does.
+//
+// DBTX deliberately does not include every method of Conn, Tx, and pgxpool.Pool. It only
+// includes the methods that are common to all three and that are useful for writing code
+// that queries the database without starting or ending a connection or transaction.
+type DBTX interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) Row
+	SendBatch(ctx context.Context, b *Batch) BatchResults
+	CopyFrom(ctx context.Context, tableName Identifier, columnNames []string, rowSrc CopyFromSource) (int64, error)
+
+	NestedBeginner
+}
+
+// compile-time checks that the standard pgx types satisfy DBTX.
+var (
+	_ DBTX = (*Conn)(nil)
+	_ DBTX = (Tx)(nil)
+)
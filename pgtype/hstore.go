@@ -0,0 +1,315 @@
+package pgtype
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// Hstore represents an hstore value: a set of key/value pairs where values may
+// individually be NULL. The map is backed by *string rather than string so that a NULL
+// value can be distinguished from an empty string, matching hstore's own semantics.
+type Hstore struct {
+	Map    map[string]*string
+	Status Status
+}
+
+// Set converts src to an Hstore. src may be a map[string]string (no value can be NULL),
+// a map[string]*string, or nil (NULL).
+func (dst *Hstore) Set(src any) error {
+	if src == nil {
+		*dst = Hstore{Status: Null}
+		return nil
+	}
+
+	switch value := src.(type) {
+	case map[string]string:
+		m := make(map[string]*string, len(value))
+		for k, v := range value {
+			v := v
+			m[k] = &v
+		}
+		*dst = Hstore{Map: m, Status: Present}
+	case map[string]*string:
+		*dst = Hstore{Map: value, Status: Present}
+	default:
+		return fmt.Errorf("cannot convert %v to Hstore", src)
+	}
+
+	return nil
+}
+
+// Get returns the map[string]*string backing dst, or nil if dst is NULL.
+func (dst Hstore) Get() any {
+	switch dst.Status {
+	case Present:
+		return dst.Map
+	case Null:
+		return nil
+	default:
+		return dst.Status
+	}
+}
+
+// AssignTo assigns src into dst, which must be a *map[string]string or a
+// *map[string]*string. Assigning a map with a NULL value into a *map[string]string
+// fails, since string cannot represent NULL.
+func (src *Hstore) AssignTo(dst any) error {
+	switch src.Status {
+	case Present:
+		switch v := dst.(type) {
+		case *map[string]string:
+			m := make(map[string]string, len(src.Map))
+			for k, val := range src.Map {
+				if val == nil {
+					return fmt.Errorf("cannot assign NULL value for key %q to map[string]string", k)
+				}
+				m[k] = *val
+			}
+			*v = m
+			return nil
+		case *map[string]*string:
+			*v = src.Map
+			return nil
+		default:
+			return fmt.Errorf("unable to assign to %T", dst)
+		}
+	case Null:
+		return NullAssignTo(dst)
+	}
+
+	return fmt.Errorf("cannot assign %v into %T", src, dst)
+}
+
+// EncodeBinary encodes src in hstore's binary wire format: an int32 pair count followed,
+// for each pair, by an int32 key length and the key bytes, then an int32 value length
+// (-1 for NULL) and the value bytes.
+func (src Hstore) EncodeBinary(ci *ConnInfo, buf []byte) ([]byte, error) {
+	switch src.Status {
+	case Null:
+		return nil, nil
+	case Undefined:
+		return nil, fmt.Errorf("cannot encode undefined Hstore")
+	}
+
+	buf = append(buf, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(buf[len(buf)-4:], uint32(len(src.Map)))
+
+	for k, v := range src.Map {
+		buf = append(buf, 0, 0, 0, 0)
+		binary.BigEndian.PutUint32(buf[len(buf)-4:], uint32(len(k)))
+		buf = append(buf, k...)
+
+		if v == nil {
+			buf = append(buf, 0xff, 0xff, 0xff, 0xff) // -1
+			continue
+		}
+
+		buf = append(buf, 0, 0, 0, 0)
+		binary.BigEndian.PutUint32(buf[len(buf)-4:], uint32(len(*v)))
+		buf = append(buf, *v...)
+	}
+
+	return buf, nil
+}
+
+// DecodeBinary decodes src from hstore's binary wire format.
+func (dst *Hstore) DecodeBinary(ci *ConnInfo, src []byte) error {
+	if src == nil {
+		*dst = Hstore{Status: Null}
+		return nil
+	}
+
+	if len(src) < 4 {
+		return fmt.Errorf("hstore: invalid binary representation: too short")
+	}
+
+	count := int(int32(binary.BigEndian.Uint32(src)))
+	rp := 4
+
+	m := make(map[string]*string, count)
+	for i := 0; i < count; i++ {
+		if len(src) < rp+4 {
+			return fmt.Errorf("hstore: invalid binary representation: truncated key length")
+		}
+		keyLen := int(int32(binary.BigEndian.Uint32(src[rp:])))
+		rp += 4
+		if keyLen < 0 || len(src) < rp+keyLen {
+			return fmt.Errorf("hstore: invalid binary representation: truncated key")
+		}
+		key := string(src[rp : rp+keyLen])
+		rp += keyLen
+
+		if len(src) < rp+4 {
+			return fmt.Errorf("hstore: invalid binary representation: truncated value length")
+		}
+		valLen := int(int32(binary.BigEndian.Uint32(src[rp:])))
+		rp += 4
+
+		if valLen == -1 {
+			m[key] = nil
+			continue
+		}
+		if valLen < 0 || len(src) < rp+valLen {
+			return fmt.Errorf("hstore: invalid binary representation: truncated value")
+		}
+		val := string(src[rp : rp+valLen])
+		rp += valLen
+		m[key] = &val
+	}
+
+	*dst = Hstore{Map: m, Status: Present}
+	return nil
+}
+
+// EncodeText encodes src in hstore's text wire format: comma-separated "key"=>"value"
+// pairs (or "key"=>NULL), with " and \ escaped inside quoted strings.
+func (src Hstore) EncodeText(ci *ConnInfo, buf []byte) ([]byte, error) {
+	switch src.Status {
+	case Null:
+		return nil, nil
+	case Undefined:
+		return nil, fmt.Errorf("cannot encode undefined Hstore")
+	}
+
+	// A Present Hstore must return a non-nil buf even when Map is empty, so that it can't
+	// be mistaken for the nil buf EncodeText returns for Null above.
+	if buf == nil {
+		buf = []byte{}
+	}
+
+	first := true
+	for k, v := range src.Map {
+		if !first {
+			buf = append(buf, ',', ' ')
+		}
+		first = false
+
+		buf = append(buf, '"')
+		buf = append(buf, hstoreEscape(k)...)
+		buf = append(buf, '"', '=', '>')
+
+		if v == nil {
+			buf = append(buf, 'N', 'U', 'L', 'L')
+			continue
+		}
+
+		buf = append(buf, '"')
+		buf = append(buf, hstoreEscape(*v)...)
+		buf = append(buf, '"')
+	}
+
+	return buf, nil
+}
+
+// DecodeText decodes src from hstore's text wire format.
+func (dst *Hstore) DecodeText(ci *ConnInfo, src []byte) error {
+	if src == nil {
+		*dst = Hstore{Status: Null}
+		return nil
+	}
+
+	m, err := parseHstoreText(string(src))
+	if err != nil {
+		return err
+	}
+
+	*dst = Hstore{Map: m, Status: Present}
+	return nil
+}
+
+func hstoreEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return s
+}
+
+// parseHstoreText parses hstore's text output format:
+//
+//	"k"=>"v", "k2"=>NULL
+func parseHstoreText(s string) (map[string]*string, error) {
+	m := make(map[string]*string)
+
+	i := 0
+	n := len(s)
+	skipSpace := func() {
+		for i < n && s[i] == ' ' {
+			i++
+		}
+	}
+	readQuoted := func() (string, error) {
+		if i >= n || s[i] != '"' {
+			return "", fmt.Errorf("hstore: expected '\"' at position %d", i)
+		}
+		i++
+		var b strings.Builder
+		for i < n {
+			switch s[i] {
+			case '\\':
+				i++
+				if i >= n {
+					return "", fmt.Errorf("hstore: unterminated escape")
+				}
+				b.WriteByte(s[i])
+				i++
+			case '"':
+				i++
+				return b.String(), nil
+			default:
+				b.WriteByte(s[i])
+				i++
+			}
+		}
+		return "", fmt.Errorf("hstore: unterminated quoted string")
+	}
+
+	skipSpace()
+	for i < n {
+		key, err := readQuoted()
+		if err != nil {
+			return nil, err
+		}
+
+		skipSpace()
+		if i+1 >= n || s[i] != '=' || s[i+1] != '>' {
+			return nil, fmt.Errorf("hstore: expected '=>' at position %d", i)
+		}
+		i += 2
+		skipSpace()
+
+		if i+3 < n && s[i:i+4] == "NULL" {
+			m[key] = nil
+			i += 4
+		} else {
+			val, err := readQuoted()
+			if err != nil {
+				return nil, err
+			}
+			m[key] = &val
+		}
+
+		skipSpace()
+		if i < n && s[i] == ',' {
+			i++
+			skipSpace()
+			continue
+		}
+		break
+	}
+
+	if i != n {
+		return nil, fmt.Errorf("hstore: unexpected trailing data at position %d", i)
+	}
+
+	return m, nil
+}
+
+// RegisterHstoreType looks up the OID of the hstore extension type with lookupOID and,
+// if the extension is installed, registers Hstore on ci under that OID. It is the
+// hstore-specific instance of the generic RegisterType; see RegisterType for the general
+// case (composites, enums, and extension-provided array types have the same "OID isn't
+// fixed" problem hstore does).
+func RegisterHstoreType(ctx context.Context, ci *ConnInfo, lookupOID func(ctx context.Context, sql string) (uint32, error)) error {
+	return RegisterType(ctx, ci, "hstore", &Hstore{}, lookupOID)
+}
@@ -0,0 +1,137 @@
+package pgtype
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHstoreSetAndAssignTo(t *testing.T) {
+	var h Hstore
+	require.NoError(t, h.Set(map[string]string{"a": "1", "b": "2"}))
+	assert.Equal(t, Present, h.Status)
+
+	var m map[string]string
+	require.NoError(t, h.AssignTo(&m))
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, m)
+}
+
+func TestHstoreAssignToRejectsNullValueIntoStringMap(t *testing.T) {
+	var h Hstore
+	one := "1"
+	require.NoError(t, h.Set(map[string]*string{"a": &one, "b": nil}))
+
+	var m map[string]string
+	assert.Error(t, h.AssignTo(&m))
+
+	var mp map[string]*string
+	require.NoError(t, h.AssignTo(&mp))
+	assert.Equal(t, &one, mp["a"])
+	assert.Nil(t, mp["b"])
+}
+
+func TestHstoreSetNull(t *testing.T) {
+	var h Hstore
+	require.NoError(t, h.Set(nil))
+	assert.Equal(t, Null, h.Status)
+	assert.Nil(t, h.Get())
+
+	var m map[string]string
+	require.NoError(t, h.AssignTo(&m))
+	assert.Nil(t, m)
+}
+
+func TestHstoreBinaryRoundTrip(t *testing.T) {
+	one := "1"
+	src := Hstore{Map: map[string]*string{"a": &one, "b": nil}, Status: Present}
+
+	buf, err := src.EncodeBinary(nil, nil)
+	require.NoError(t, err)
+
+	var dst Hstore
+	require.NoError(t, dst.DecodeBinary(nil, buf))
+	assert.Equal(t, Present, dst.Status)
+	require.Contains(t, dst.Map, "a")
+	require.NotNil(t, dst.Map["a"])
+	assert.Equal(t, "1", *dst.Map["a"])
+	require.Contains(t, dst.Map, "b")
+	assert.Nil(t, dst.Map["b"])
+}
+
+func TestHstoreBinaryNull(t *testing.T) {
+	src := Hstore{Status: Null}
+	buf, err := src.EncodeBinary(nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, buf)
+
+	var dst Hstore
+	require.NoError(t, dst.DecodeBinary(nil, nil))
+	assert.Equal(t, Null, dst.Status)
+}
+
+func TestHstoreTextRoundTrip(t *testing.T) {
+	one := "say \"hi\""
+	src := Hstore{Map: map[string]*string{"a": &one, "b": nil}, Status: Present}
+
+	buf, err := src.EncodeText(nil, nil)
+	require.NoError(t, err)
+
+	var dst Hstore
+	require.NoError(t, dst.DecodeText(nil, buf))
+	require.NotNil(t, dst.Map["a"])
+	assert.Equal(t, one, *dst.Map["a"])
+	assert.Nil(t, dst.Map["b"])
+}
+
+func TestHstoreTextEmptyMap(t *testing.T) {
+	src := Hstore{Map: map[string]*string{}, Status: Present}
+
+	buf, err := src.EncodeText(nil, nil)
+	require.NoError(t, err)
+	assert.NotNil(t, buf)
+	assert.Len(t, buf, 0)
+
+	var dst Hstore
+	require.NoError(t, dst.DecodeText(nil, buf))
+	assert.Equal(t, Present, dst.Status)
+	assert.Empty(t, dst.Map)
+}
+
+func TestParseHstoreText(t *testing.T) {
+	m, err := parseHstoreText(`"a"=>"1", "b"=>NULL`)
+	require.NoError(t, err)
+	require.NotNil(t, m["a"])
+	assert.Equal(t, "1", *m["a"])
+	assert.Nil(t, m["b"])
+}
+
+func TestRegisterHstoreType(t *testing.T) {
+	ci := NewConnInfo()
+
+	err := RegisterHstoreType(context.Background(), ci, func(ctx context.Context, sql string) (uint32, error) {
+		return 16000, nil
+	})
+	require.NoError(t, err)
+
+	dt, ok := ci.DataTypeForName("hstore")
+	require.True(t, ok)
+	assert.Equal(t, uint32(16000), dt.OID)
+
+	dt, ok = ci.DataTypeForOID(16000)
+	require.True(t, ok)
+	assert.Equal(t, "hstore", dt.Name)
+}
+
+func TestRegisterHstoreTypeNotInstalled(t *testing.T) {
+	ci := NewConnInfo()
+
+	err := RegisterHstoreType(context.Background(), ci, func(ctx context.Context, sql string) (uint32, error) {
+		return 0, nil
+	})
+	require.NoError(t, err)
+
+	_, ok := ci.DataTypeForName("hstore")
+	assert.False(t, ok)
+}
@@ -0,0 +1,194 @@
+// Package pgtype implements Go types for the PostgreSQL types that do not map directly
+// to a native Go type, plus the ConnInfo registry that maps between a connection's OIDs
+// and those types. See the Custom Type Support section of package pgx's documentation
+// for how to implement a new type.
+//
+// ConnInfo and the Set/AssignTo/EncodeBinary/DecodeBinary/EncodeText/DecodeText methods
+// on each type are this package's only API: there is no separate Map/Codec layer. That
+// matters for extension types like Hstore, whose OID is not fixed across servers and
+// must be looked up against pg_type and registered per connection at runtime (see
+// RegisterHstoreType) — a registry built from a static, init-time mapping of OIDs to
+// codecs cannot represent that, so types here are registered directly on ConnInfo
+// instead.
+package pgtype
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Status represents the presence of a value, distinguishing a SQL NULL from both a
+// present value and a value that has never been set.
+type Status byte
+
+const (
+	Undefined Status = iota
+	Null
+	Present
+)
+
+// Value is implemented by every pgtype type. Set converts a Go value (such as a native
+// map or slice) into the type's internal representation. AssignTo converts the other
+// way, assigning the type's value into a Go destination pointer, honoring any null value
+// the same way database/sql's Scan does.
+type Value interface {
+	Set(src any) error
+	Get() any
+	AssignTo(dst any) error
+}
+
+// BinaryEncoder is implemented by types that can encode themselves to the PostgreSQL
+// binary wire format.
+type BinaryEncoder interface {
+	EncodeBinary(ci *ConnInfo, buf []byte) (newBuf []byte, err error)
+}
+
+// BinaryDecoder is implemented by types that can decode themselves from the PostgreSQL
+// binary wire format.
+type BinaryDecoder interface {
+	DecodeBinary(ci *ConnInfo, src []byte) error
+}
+
+// TextEncoder is implemented by types that can encode themselves to the PostgreSQL text
+// wire format.
+type TextEncoder interface {
+	EncodeText(ci *ConnInfo, buf []byte) (newBuf []byte, err error)
+}
+
+// TextDecoder is implemented by types that can decode themselves from the PostgreSQL
+// text wire format.
+type TextDecoder interface {
+	DecodeText(ci *ConnInfo, src []byte) error
+}
+
+// DataType associates a Value implementation with the name and OID of the PostgreSQL
+// type it represents on a particular connection.
+type DataType struct {
+	Value Value
+	Name  string
+	OID   uint32
+}
+
+// ConnInfo holds a connection's mapping between PostgreSQL type OIDs and pgtype Values.
+// Most types have a fixed, well-known OID that NewConnInfo seeds automatically. Types
+// such as hstore whose OID depends on how an extension was installed must be looked up
+// against the connection and registered with RegisterDataType before first use (see
+// RegisterHstoreType).
+type ConnInfo struct {
+	oidToDataType  map[uint32]*DataType
+	nameToDataType map[string]*DataType
+}
+
+// NewConnInfo returns a ConnInfo seeded with the common builtin PostgreSQL types.
+func NewConnInfo() *ConnInfo {
+	ci := &ConnInfo{
+		oidToDataType:  make(map[uint32]*DataType),
+		nameToDataType: make(map[string]*DataType),
+	}
+	for _, dt := range builtinDataTypes {
+		dt := dt
+		ci.RegisterDataType(dt)
+	}
+	return ci
+}
+
+// RegisterDataType adds or replaces dt's entry in ci, indexed by both OID and name.
+func (ci *ConnInfo) RegisterDataType(dt DataType) {
+	d := dt
+	ci.oidToDataType[dt.OID] = &d
+	ci.nameToDataType[dt.Name] = &d
+}
+
+// DataTypeForOID returns the DataType registered under oid, if any.
+func (ci *ConnInfo) DataTypeForOID(oid uint32) (*DataType, bool) {
+	dt, ok := ci.oidToDataType[oid]
+	return dt, ok
+}
+
+// DataTypeForName returns the DataType registered under name, if any.
+func (ci *ConnInfo) DataTypeForName(name string) (*DataType, bool) {
+	dt, ok := ci.nameToDataType[name]
+	return dt, ok
+}
+
+// builtinDataTypes is the subset of PostgreSQL's fixed-OID builtin types pgtype maps by
+// default. Extension types such as hstore are not included here because their OID is
+// not fixed; see RegisterHstoreType. These entries record only a name and an OID: their
+// Value is nil.
+var builtinDataTypes = []DataType{
+	{Name: "bool", OID: 16},
+	{Name: "bytea", OID: 17},
+	{Name: "int8", OID: 20},
+	{Name: "int2", OID: 21},
+	{Name: "int4", OID: 23},
+	{Name: "text", OID: 25},
+	{Name: "json", OID: 114},
+	{Name: "float4", OID: 700},
+	{Name: "float8", OID: 701},
+	{Name: "bpchar", OID: 1042},
+	{Name: "varchar", OID: 1043},
+	{Name: "date", OID: 1082},
+	{Name: "timestamp", OID: 1114},
+	{Name: "timestamptz", OID: 1184},
+	{Name: "uuid", OID: 2950},
+	{Name: "jsonb", OID: 3802},
+}
+
+// RegisterType looks up the OID of the PostgreSQL type named name (a pg_type.typname
+// value) using lookupOID and, if found, registers value on ci under that OID.
+//
+// RegisterType exists because not every type has a fixed OID the way the builtins in
+// builtinDataTypes do: composites, enums, and extension-provided types (hstore among
+// them — see RegisterHstoreType) get their OID assigned when the type is created, so it
+// must be looked up against the connection rather than hardcoded. lookupOID is typically
+// a closure around (*pgx.Conn).QueryRow or (*pgxpool.Pool).QueryRow that runs the SQL it
+// is given and returns the single uint32 column it selects:
+//
+//	err := pgtype.RegisterType(ctx, conn.ConnInfo(), "my_enum", &MyEnum{}, func(ctx context.Context, sql string) (uint32, error) {
+//		var oid uint32
+//		err := conn.QueryRow(ctx, sql).Scan(&oid)
+//		return oid, err
+//	})
+//
+// RegisterType is a no-op, returning a nil error, if name has no matching row in
+// pg_type — the type (or the extension providing it) is simply not installed on this
+// server.
+func RegisterType(ctx context.Context, ci *ConnInfo, name string, value Value, lookupOID func(ctx context.Context, sql string) (uint32, error)) error {
+	oid, err := lookupOID(ctx, fmt.Sprintf("select t.oid from pg_catalog.pg_type t where t.typname = %s", quoteLiteral(name)))
+	if err != nil {
+		return fmt.Errorf("pgtype: looking up %s OID: %w", name, err)
+	}
+	if oid == 0 {
+		return nil
+	}
+
+	ci.RegisterDataType(DataType{Value: value, Name: name, OID: oid})
+	return nil
+}
+
+// quoteLiteral quotes s as a PostgreSQL string literal. name is always a Go-level
+// constant or developer-supplied type name here, never untrusted input, but quoting it
+// properly costs nothing and keeps RegisterType safe to call with any name.
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// NullAssignTo is the AssignTo behavior shared by every pgtype type when its Status is
+// Null: a **string, *map[string]string or *map[string]*string destination is set to nil,
+// and any other destination is left untouched and an error is returned.
+func NullAssignTo(dst any) error {
+	switch v := dst.(type) {
+	case **string:
+		*v = nil
+		return nil
+	case *map[string]string:
+		*v = nil
+		return nil
+	case *map[string]*string:
+		*v = nil
+		return nil
+	default:
+		return fmt.Errorf("cannot assign NULL to %T", dst)
+	}
+}
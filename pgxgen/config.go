@@ -0,0 +1,55 @@
+package pgxgen
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the optional YAML configuration file accepted via pgxgen's --config flag.
+// Its go-type list is equivalent to repeated --go-type flags. Package and Out are parsed
+// but cmd/pgxgen does not apply them: the generated package name and output path come
+// only from its -package and -out flags.
+//
+//	package: queries
+//	out: db/queries.gen.go
+//	go-type:
+//	  - pg-type: hstore
+//	    go-type: map[string]string
+//	  - pg-type: date
+//	    go-type: "*time.Time"
+type Config struct {
+	Package string            `yaml:"package"`
+	Out     string            `yaml:"out"`
+	GoType  []ConfigTypeEntry `yaml:"go-type"`
+}
+
+// ConfigTypeEntry is one entry of the go-type list in a Config file. It is equivalent to
+// a single --go-type pg-type=go-type flag.
+type ConfigTypeEntry struct {
+	PgType string `yaml:"pg-type"`
+	GoType string `yaml:"go-type"`
+}
+
+// LoadConfig parses a pgxgen YAML config file.
+func LoadConfig(src []byte) (*Config, error) {
+	var cfg Config
+	if err := yaml.Unmarshal(src, &cfg); err != nil {
+		return nil, fmt.Errorf("pgxgen: parsing config: %w", err)
+	}
+	return &cfg, nil
+}
+
+// TypeOverrides converts the config's go-type entries into TypeOverrides, resolving the
+// import path for each the same way a --go-type flag would.
+func (c *Config) TypeOverrides() ([]TypeOverride, error) {
+	overrides := make([]TypeOverride, 0, len(c.GoType))
+	for _, e := range c.GoType {
+		imp, err := GoTypeImport(e.GoType)
+		if err != nil {
+			return nil, fmt.Errorf("pgxgen: config go-type %s=%s: %w", e.PgType, e.GoType, err)
+		}
+		overrides = append(overrides, TypeOverride{PgType: e.PgType, GoType: e.GoType, Import: imp})
+	}
+	return overrides, nil
+}
@@ -0,0 +1,20 @@
+// Package pgxgen implements the code generator behind the cmd/pgxgen tool. It reads a
+// directory of annotated SQL files, connects to a live PostgreSQL server to introspect
+// parameter and result types via pgconn's Describe support, and emits a Queries struct
+// with one Go method per annotated query. Generated methods operate on a pgx.DBTX, so
+// the same Queries value works against a *pgx.Conn, a pgx.Tx, or a *pgxpool.Pool.
+//
+// A query is annotated with a directive comment immediately above its SQL:
+//
+//	-- name: FindUsersByOrg :many
+//	select id, name from users where org_id = $1;
+//
+// The directive's command determines the shape of the generated method: :many returns
+// a slice collected with pgx.CollectRows, :one returns a single row, :exec returns only
+// a pgconn.CommandTag, and :batch emits a method built around SendBatch.
+//
+// Resolver maps parameter and result OIDs to Go types using a *pgtype.ConnInfo (see
+// package pgtype). Extension types whose OID is not fixed, such as hstore, must already
+// be registered on that ConnInfo before Resolve is called — for hstore, by calling
+// pgtype.RegisterHstoreType once per connection.
+package pgxgen
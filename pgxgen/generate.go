@@ -0,0 +1,195 @@
+package pgxgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Generate renders a complete Go source file implementing the methods for queries, in
+// package pkgName: one method per :many, :one or :exec query, and a Batch method plus a
+// BatchResults type per :batch query. The returned source is passed through go/format before it is
+// returned, so templates do not need to produce perfectly indented output.
+//
+// Every import required by a query's resolved Go types (see TypeOverride.Import) is
+// collected across all queries and deduplicated before being written to the file's
+// import block; see GoTypeImport for why that matters.
+func Generate(pkgName string, queries []ResolvedQuery) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := generateTmpl.Execute(&buf, struct {
+		Package string
+		Imports []string
+		Queries []ResolvedQuery
+	}{
+		Package: pkgName,
+		Imports: GenerateImports(queries),
+		Queries: queries,
+	}); err != nil {
+		return nil, fmt.Errorf("pgxgen: executing template: %w", err)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("pgxgen: formatting generated source: %w", err)
+	}
+	return formatted, nil
+}
+
+// GenerateImports returns the sorted, deduplicated set of import paths required to
+// compile the generated methods for queries: pgx (always used by the Queries struct),
+// context when any :many, :one or :exec method is generated, pgconn when any :exec
+// method is generated, plus every TypeOverride.Import referenced by any query's
+// parameters or result columns.
+func GenerateImports(queries []ResolvedQuery) []string {
+	set := map[string]struct{}{
+		"github.com/jackc/pgx/v5": {},
+	}
+	for _, q := range queries {
+		switch q.Cmd {
+		case CmdMany, CmdOne:
+			set["context"] = struct{}{}
+		case CmdExec:
+			set["context"] = struct{}{}
+			set["github.com/jackc/pgx/v5/pgconn"] = struct{}{}
+		}
+		for _, p := range q.Params {
+			if imp := importOf(p.GoType); imp != "" {
+				set[imp] = struct{}{}
+			}
+		}
+		for _, c := range q.Columns {
+			if imp := importOf(c.GoType); imp != "" {
+				set[imp] = struct{}{}
+			}
+		}
+	}
+
+	imports := make([]string, 0, len(set))
+	for imp := range set {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+	return imports
+}
+
+func importOf(goType string) string {
+	imp, err := GoTypeImport(goType)
+	if err != nil {
+		return ""
+	}
+	return imp
+}
+
+// exportField converts a snake_case column name (e.g. "org_id") into an exported Go
+// struct field name (e.g. "OrgID").
+func exportField(name string) string {
+	parts := strings.Split(name, "_")
+	for i, p := range parts {
+		if p == "" {
+			continue
+		}
+		switch strings.ToLower(p) {
+		case "id", "oid", "uuid", "url", "db":
+			parts[i] = strings.ToUpper(p)
+		default:
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+var generateTmpl = template.Must(template.New("pgxgen").Funcs(template.FuncMap{
+	"join":        strings.Join,
+	"exportField": exportField,
+}).Parse(`// Code generated by pgxgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+
+// Queries wraps a pgx.DBTX so generated methods run the same way against a connection,
+// a transaction, or a pool.
+type Queries struct {
+	db pgx.DBTX
+}
+
+func New(db pgx.DBTX) *Queries {
+	return &Queries{db: db}
+}
+{{range .Queries}}
+{{if eq .Cmd "many"}}
+func (q *Queries) {{.Name}}(ctx context.Context{{range $i, $p := .Params}}, arg{{$i}} {{$p.GoType}}{{end}}) ([]{{.Name}}Row, error) {
+	rows, err := q.db.Query(ctx, {{printf "%q" .SQL}}{{range $i, $p := .Params}}, arg{{$i}}{{end}})
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[{{.Name}}Row])
+}
+{{else if eq .Cmd "one"}}
+func (q *Queries) {{.Name}}(ctx context.Context{{range $i, $p := .Params}}, arg{{$i}} {{$p.GoType}}{{end}}) ({{.Name}}Row, error) {
+	row := q.db.QueryRow(ctx, {{printf "%q" .SQL}}{{range $i, $p := .Params}}, arg{{$i}}{{end}})
+	var result {{.Name}}Row
+	err := row.Scan({{range $i, $c := .Columns}}{{if $i}}, {{end}}&result.{{$c.Name | exportField}}{{end}})
+	return result, err
+}
+{{else if eq .Cmd "exec"}}
+func (q *Queries) {{.Name}}(ctx context.Context{{range $i, $p := .Params}}, arg{{$i}} {{$p.GoType}}{{end}}) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, {{printf "%q" .SQL}}{{range $i, $p := .Params}}, arg{{$i}}{{end}})
+}
+{{else if eq .Cmd "batch"}}
+// {{.Name}}BatchParams is one set of arguments queued by {{.Name}}Batch.
+type {{.Name}}BatchParams struct {
+{{- range $i, $p := .Params}}
+	Arg{{$i}} {{$p.GoType}}
+{{- end}}
+}
+
+// {{.Name}}Batch queues one {{.Name}} execution per element of params onto batch so they
+// run as a single round trip via SendBatch.
+func (q *Queries) {{.Name}}Batch(batch *pgx.Batch, params []{{.Name}}BatchParams) {
+	for _, p := range params {
+		batch.Queue({{printf "%q" .SQL}}{{range $i, $p := .Params}}, p.Arg{{$i}}{{end}})
+	}
+}
+
+// {{.Name}}BatchResults scans the results of a batch built by {{.Name}}Batch, one
+// {{.Name}}Row per queued {{.Name}}BatchParams, in the order they were queued.
+type {{.Name}}BatchResults struct {
+	br pgx.BatchResults
+	n  int
+}
+
+// New{{.Name}}BatchResults wraps br, the result of calling SendBatch on a batch built by
+// {{.Name}}Batch with n queued params.
+func New{{.Name}}BatchResults(br pgx.BatchResults, n int) *{{.Name}}BatchResults {
+	return &{{.Name}}BatchResults{br: br, n: n}
+}
+
+// Scan calls f once per queued result, in the order {{.Name}}Batch queued them, and
+// closes the underlying BatchResults once every result has been read.
+func (r *{{.Name}}BatchResults) Scan(f func(i int, row {{.Name}}Row, err error)) error {
+	for i := 0; i < r.n; i++ {
+		row := r.br.QueryRow()
+		var result {{.Name}}Row
+		err := row.Scan({{range $i, $c := .Columns}}{{if $i}}, {{end}}&result.{{$c.Name | exportField}}{{end}})
+		f(i, result, err)
+	}
+	return r.br.Close()
+}
+{{end}}
+{{if ne .Cmd "exec"}}
+type {{.Name}}Row struct {
+{{- range .Columns}}
+	{{.Name | exportField}} {{.GoType}}
+{{- end}}
+}
+{{end}}
+{{end}}
+`))
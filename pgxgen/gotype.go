@@ -0,0 +1,87 @@
+package pgxgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TypeOverride maps a Postgres type name (as it appears in pg_type.typname, e.g.
+// "hstore", "date", "_int4") to a Go type, optionally qualified with the import path
+// that type requires.
+type TypeOverride struct {
+	PgType string
+	GoType string // as written in generated source, e.g. "map[string]string", "*time.Time"
+	Import string // import path required by GoType, if any
+}
+
+// ParseGoTypeFlag parses the value of a repeated --go-type pgtype=gotype flag, e.g.
+//
+//	--go-type hstore=map[string]string
+//	--go-type date=*time.Time
+//	--go-type uuid=github.com/google/uuid.UUID
+//
+// The import path is inferred from the Go type: a dotted, slash-containing prefix is
+// taken verbatim as the import path, a dotted prefix with no slash is assumed to be a
+// standard library package name, and a type with no dot (builtins, map, slice, pointer
+// to builtin) requires no import. See GoTypeImport for why this inference matters.
+func ParseGoTypeFlag(s string) (TypeOverride, error) {
+	pgType, goType, ok := strings.Cut(s, "=")
+	if !ok || pgType == "" || goType == "" {
+		return TypeOverride{}, fmt.Errorf("pgxgen: invalid --go-type %q: expected pgtype=gotype", s)
+	}
+	imp, err := GoTypeImport(goType)
+	if err != nil {
+		return TypeOverride{}, fmt.Errorf("pgxgen: invalid --go-type %q: %w", s, err)
+	}
+	return TypeOverride{PgType: pgType, GoType: goType, Import: imp}, nil
+}
+
+// GoTypeImport returns the import path required to reference goType in generated code,
+// or "" if goType is composed entirely of predeclared identifiers and literal map/slice
+// syntax. An error is returned only if goType has a package-qualified name but the
+// import path cannot be determined from it.
+//
+// This inference is the precise spot pggen issue #70 got wrong for externally-imported
+// types: a generated file referenced a package (e.g. github.com/google/uuid) it never
+// imported. GoTypeImport has explicit unit tests covering every branch to guard against
+// a regression here; callers that rely on its result (ParseGoTypeFlag, GenerateImports)
+// don't repeat the rationale themselves.
+func GoTypeImport(goType string) (string, error) {
+	bare := strings.TrimLeft(goType, "*[]")
+
+	// Peel map[K] and []T wrappers down to the element type so "map[string]*time.Time"
+	// resolves the import for "time.Time", not for the wrapper itself.
+	for {
+		switch {
+		case strings.HasPrefix(bare, "map["):
+			close := strings.Index(bare, "]")
+			if close == -1 {
+				return "", fmt.Errorf("malformed map type %q", goType)
+			}
+			bare = strings.TrimLeft(bare[close+1:], "*[]")
+			continue
+		}
+		break
+	}
+
+	if !strings.Contains(bare, ".") {
+		return "", nil
+	}
+
+	if slash := strings.LastIndex(bare, "/"); slash != -1 {
+		dot := strings.LastIndex(bare, ".")
+		if dot <= slash {
+			return "", fmt.Errorf("type %q has an import path but no package-qualified type name", goType)
+		}
+		return bare[:dot], nil
+	}
+
+	// No slash: a single dotted segment is assumed to be a standard library package,
+	// e.g. "time.Time" or "netip.Addr". Anything else must be written with its full
+	// import path so the generator never has to guess at a package's import location.
+	dot := strings.Index(bare, ".")
+	if strings.Count(bare, ".") != 1 {
+		return "", fmt.Errorf("type %q is not a standard library type and must be written as its full import path, e.g. github.com/google/uuid.UUID", goType)
+	}
+	return bare[:dot], nil
+}
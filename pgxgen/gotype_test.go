@@ -0,0 +1,72 @@
+package pgxgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGoTypeImport covers every branch described in GoTypeImport's doc comment,
+// including the externally-imported case (e.g. github.com/google/uuid.UUID) that
+// GenerateImports relies on it to get right.
+func TestGoTypeImport(t *testing.T) {
+	tests := []struct {
+		goType  string
+		wantImp string
+		wantErr bool
+	}{
+		{goType: "string", wantImp: ""},
+		{goType: "int32", wantImp: ""},
+		{goType: "[]byte", wantImp: ""},
+		{goType: "map[string]string", wantImp: ""},
+		{goType: "map[string]*string", wantImp: ""},
+		{goType: "time.Time", wantImp: "time"},
+		{goType: "*time.Time", wantImp: "time"},
+		{goType: "map[string]time.Time", wantImp: "time"},
+		{goType: "github.com/google/uuid.UUID", wantImp: "github.com/google/uuid"},
+		{goType: "*github.com/google/uuid.UUID", wantImp: "github.com/google/uuid"},
+		{goType: "github.com/google/uuid", wantErr: true},
+		{goType: "not.a.real.stdlib.Type", wantErr: true},
+		{goType: "map[string", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goType, func(t *testing.T) {
+			imp, err := GoTypeImport(tt.goType)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantImp, imp)
+		})
+	}
+}
+
+func TestParseGoTypeFlag(t *testing.T) {
+	ov, err := ParseGoTypeFlag("hstore=map[string]string")
+	require.NoError(t, err)
+	assert.Equal(t, TypeOverride{PgType: "hstore", GoType: "map[string]string", Import: ""}, ov)
+
+	ov, err = ParseGoTypeFlag("date=time.Time")
+	require.NoError(t, err)
+	assert.Equal(t, TypeOverride{PgType: "date", GoType: "time.Time", Import: "time"}, ov)
+
+	_, err = ParseGoTypeFlag("missing-equals")
+	assert.Error(t, err)
+}
+
+func TestGenerateImportsIncludesOverrideImports(t *testing.T) {
+	queries := []ResolvedQuery{
+		{
+			Query:   Query{Name: "FindEvent"},
+			Params:  []Param{{GoType: "int32"}},
+			Columns: []Column{{Name: "starts_at", GoType: "time.Time"}, {Name: "id", GoType: "github.com/google/uuid.UUID"}},
+		},
+	}
+
+	imports := GenerateImports(queries)
+	assert.Contains(t, imports, "time")
+	assert.Contains(t, imports, "github.com/google/uuid")
+}
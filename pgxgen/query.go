@@ -0,0 +1,83 @@
+package pgxgen
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Cmd is the kind of method a query directive requests.
+type Cmd string
+
+const (
+	CmdMany  Cmd = "many"
+	CmdOne   Cmd = "one"
+	CmdExec  Cmd = "exec"
+	CmdBatch Cmd = "batch"
+)
+
+// Query is a single annotated SQL statement parsed out of a source file, before its
+// parameter and result types have been resolved against a live connection.
+type Query struct {
+	Name       string
+	Cmd        Cmd
+	SQL        string
+	SourceFile string
+	Line       int
+}
+
+var directiveRe = regexp.MustCompile(`^--\s*name:\s*([A-Za-z_][A-Za-z0-9_]*)\s+:(many|one|exec|batch)\s*$`)
+
+// ParseQueries splits src on "-- name: Name :cmd" directive comments and returns one
+// Query per directive, in the order they appear. Lines before the first directive are
+// ignored, matching the behavior of comparable tools like pggen and sqlc.
+func ParseQueries(filename string, src []byte) ([]Query, error) {
+	var queries []Query
+	var cur *Query
+	var body strings.Builder
+
+	flush := func() {
+		if cur != nil {
+			cur.SQL = strings.TrimSpace(body.String())
+			queries = append(queries, *cur)
+		}
+		cur = nil
+		body.Reset()
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(src))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+		if m := directiveRe.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			flush()
+			cur = &Query{
+				Name:       m[1],
+				Cmd:        Cmd(m[2]),
+				SourceFile: filename,
+				Line:       lineNo,
+			}
+			continue
+		}
+		if cur != nil {
+			body.WriteString(line)
+			body.WriteByte('\n')
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("pgxgen: reading %s: %w", filename, err)
+	}
+	flush()
+
+	for _, q := range queries {
+		if q.SQL == "" {
+			return nil, fmt.Errorf("pgxgen: %s:%d: query %q has a name directive but no SQL", filename, q.Line, q.Name)
+		}
+	}
+
+	return queries, nil
+}
@@ -0,0 +1,54 @@
+package pgxgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQueries(t *testing.T) {
+	src := []byte(`-- name: FindUsersByOrg :many
+select id, name from users where org_id = $1;
+
+-- name: FindUserByID :one
+select id, name from users where id = $1;
+
+-- name: DeleteUser :exec
+delete from users where id = $1;
+`)
+
+	queries, err := ParseQueries("users.sql", src)
+	require.NoError(t, err)
+	require.Len(t, queries, 3)
+
+	assert.Equal(t, "FindUsersByOrg", queries[0].Name)
+	assert.Equal(t, CmdMany, queries[0].Cmd)
+	assert.Contains(t, queries[0].SQL, "select id, name from users where org_id = $1;")
+
+	assert.Equal(t, "FindUserByID", queries[1].Name)
+	assert.Equal(t, CmdOne, queries[1].Cmd)
+
+	assert.Equal(t, "DeleteUser", queries[2].Name)
+	assert.Equal(t, CmdExec, queries[2].Cmd)
+}
+
+func TestParseQueriesIgnoresLeadingComments(t *testing.T) {
+	src := []byte(`-- this file defines the user queries
+-- name: FindUserByID :one
+select id from users where id = $1;
+`)
+	queries, err := ParseQueries("users.sql", src)
+	require.NoError(t, err)
+	require.Len(t, queries, 1)
+	assert.Equal(t, "FindUserByID", queries[0].Name)
+}
+
+func TestParseQueriesRejectsEmptyBody(t *testing.T) {
+	src := []byte(`-- name: FindUserByID :one
+-- name: DeleteUser :exec
+delete from users where id = $1;
+`)
+	_, err := ParseQueries("users.sql", src)
+	assert.Error(t, err)
+}
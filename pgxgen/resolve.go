@@ -0,0 +1,115 @@
+package pgxgen
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Param is a single positional parameter of a resolved query, in $1, $2, ... order.
+type Param struct {
+	OID    uint32
+	GoType string
+}
+
+// Column is a single result column of a resolved query.
+type Column struct {
+	Name   string
+	OID    uint32
+	GoType string
+}
+
+// ResolvedQuery is a Query whose parameter and result OIDs have been described against a
+// live connection and mapped to Go types.
+type ResolvedQuery struct {
+	Query
+	Params  []Param
+	Columns []Column
+}
+
+// Resolver describes queries against a live connection and maps the resulting OIDs to
+// Go types, applying any configured TypeOverrides.
+type Resolver struct {
+	pgConn    *pgconn.PgConn
+	connInfo  *pgtype.ConnInfo
+	overrides map[string]TypeOverride
+}
+
+// NewResolver builds a Resolver backed by pgConn. connInfo is used both to resolve
+// builtin OIDs to their default Go representation and to look up the pg_type name
+// backing any OID an override targets by name (e.g. "hstore", whose OID is not fixed
+// across installations, so connInfo must already have it registered via
+// pgtype.RegisterHstoreType before Resolve is called).
+func NewResolver(pgConn *pgconn.PgConn, connInfo *pgtype.ConnInfo, overrides []TypeOverride) *Resolver {
+	byName := make(map[string]TypeOverride, len(overrides))
+	for _, o := range overrides {
+		byName[o.PgType] = o
+	}
+	return &Resolver{pgConn: pgConn, connInfo: connInfo, overrides: byName}
+}
+
+// Resolve describes q against the live connection and maps its parameter and result
+// OIDs to Go types. It does not execute q; PostgreSQL can describe a prepared statement
+// without running it.
+func (r *Resolver) Resolve(ctx context.Context, q Query) (ResolvedQuery, error) {
+	sd, err := r.pgConn.Prepare(ctx, "", q.SQL, nil)
+	if err != nil {
+		return ResolvedQuery{}, fmt.Errorf("pgxgen: describing %s: %w", q.Name, err)
+	}
+
+	out := ResolvedQuery{Query: q}
+
+	for _, oid := range sd.ParamOIDs {
+		out.Params = append(out.Params, Param{OID: oid, GoType: r.goType(oid)})
+	}
+	for _, fd := range sd.Fields {
+		out.Columns = append(out.Columns, Column{
+			Name:   fd.Name,
+			OID:    fd.DataTypeOID,
+			GoType: r.goType(fd.DataTypeOID),
+		})
+	}
+
+	return out, nil
+}
+
+// goType returns the Go type pgxgen should use for oid: a configured override if the
+// connection's pg_type name for oid has one, otherwise the type registered in
+// connInfo, falling back to "any" for anything unrecognized.
+func (r *Resolver) goType(oid uint32) string {
+	dt, ok := r.connInfo.DataTypeForOID(oid)
+	if !ok {
+		return "any"
+	}
+	if o, ok := r.overrides[dt.Name]; ok {
+		return o.GoType
+	}
+	if gt, ok := defaultGoTypes[dt.Name]; ok {
+		return gt
+	}
+	return "any"
+}
+
+// defaultGoTypes covers the common builtin Postgres types pgxgen maps without needing a
+// --go-type override. Types not listed here (including extension types like hstore)
+// require an explicit override.
+var defaultGoTypes = map[string]string{
+	"int2":        "int16",
+	"int4":        "int32",
+	"int8":        "int64",
+	"float4":      "float32",
+	"float8":      "float64",
+	"text":        "string",
+	"varchar":     "string",
+	"bpchar":      "string",
+	"bool":        "bool",
+	"bytea":       "[]byte",
+	"date":        "time.Time",
+	"timestamp":   "time.Time",
+	"timestamptz": "time.Time",
+	"uuid":        "[16]byte",
+	"jsonb":       "[]byte",
+	"json":        "[]byte",
+}
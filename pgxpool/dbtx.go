@@ -0,0 +1,7 @@
+package pgxpool
+
+import "github.com/jackc/pgx/v5"
+
+// *Pool satisfies pgx.DBTX: Exec, Query, QueryRow, SendBatch, CopyFrom, and Begin all
+// already have matching signatures on *Pool, so no adapter methods are needed here.
+var _ pgx.DBTX = (*Pool)(nil)